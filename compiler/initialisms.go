@@ -0,0 +1,129 @@
+package compiler
+
+import (
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms is the default set of initialisms that are
+// preserved in their canonical (all-caps) form instead of being
+// title-cased like a regular word. It's seeded with the same list
+// golint uses, since that's what most Go/Java consumers of the
+// generated .proto will already expect.
+var commonInitialisms = map[string]bool{
+	"ACL":   true,
+	"API":   true,
+	"ASCII": true,
+	"CPU":   true,
+	"DNS":   true,
+	"EOF":   true,
+	"GUID":  true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"IO":    true,
+	"JSON":  true,
+	"RAM":   true,
+	"SMTP":  true,
+	"SQL":   true,
+	"SSH":   true,
+	"TCP":   true,
+	"TLS":   true,
+	"UDP":   true,
+	"UI":    true,
+	"UUID":  true,
+	"URI":   true,
+	"URL":   true,
+	"XML":   true,
+}
+
+// defaultInitialisms returns a fresh copy of commonInitialisms so
+// callers can mutate it (via WithInitialisms) without affecting the
+// package-level default.
+func defaultInitialisms() map[string]bool {
+	m := make(map[string]bool, len(commonInitialisms))
+	for k, v := range commonInitialisms {
+		m[k] = v
+	}
+	return m
+}
+
+// WithInitialisms merges the given set of initialisms into the
+// default list, so that words matching any key (case-insensitively)
+// are emitted in their canonical form rather than being title-cased.
+// Pass a value of false to remove a default initialism.
+func WithInitialisms(initialisms map[string]bool) Option {
+	return func(o *Options) {
+		for word, ok := range initialisms {
+			word = strings.ToUpper(word)
+			if ok {
+				o.initialisms[word] = true
+			} else {
+				delete(o.initialisms, word)
+			}
+		}
+	}
+}
+
+// WithoutInitialisms disables initialism handling entirely, restoring
+// the plain title-casing behavior.
+func WithoutInitialisms() Option {
+	return func(o *Options) {
+		o.initialisms = map[string]bool{}
+	}
+}
+
+// applyInitialisms title-cases word, except that if it matches one of
+// the configured initialisms (case-insensitively) it is emitted in its
+// canonical form instead.
+func applyInitialisms(o *Options, word string) string {
+	if word == "" {
+		return word
+	}
+	if canonical, ok := o.initialisms[strings.ToUpper(word)]; ok && canonical {
+		return strings.ToUpper(word)
+	}
+	return strings.Title(strings.ToLower(word))
+}
+
+// splitWords breaks s into its constituent words, treating any
+// non-alphanumeric rune as a separator and additionally splitting
+// camelCase/PascalCase boundaries (e.g. "userId" -> "user", "Id", and
+// "HTTPServer" -> "HTTP", "Server"), so that each word can be
+// evaluated against the initialisms set on its own.
+func splitWords(s string) []string {
+	runes := []rune(s)
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		if !isAlphaNum(r) {
+			flush()
+			continue
+		}
+		if i > 0 {
+			prev := runes[i-1]
+			switch {
+			case unicode.IsLower(prev) && unicode.IsUpper(r):
+				// "user|Id"
+				flush()
+			case unicode.IsUpper(prev) && unicode.IsUpper(r) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+				// "HTTP|Server"
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
@@ -0,0 +1,27 @@
+package compiler
+
+import "testing"
+
+func TestServiceNameInitialisms(t *testing.T) {
+	o := newOptions()
+	if got, want := serviceName(o, "user api"), "UserAPIService"; got != want {
+		t.Errorf("serviceName(%q) = %q, want %q", "user api", got, want)
+	}
+}
+
+func TestNormalizeEnumNameInitialisms(t *testing.T) {
+	o := newOptions()
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"api_key", "API_key"},
+		{"userId", "userID"},
+		{"ACTIVE", "ACTIVE"},
+	}
+	for _, tt := range tests {
+		if got := normalizeEnumName(o, tt.in); got != tt.want {
+			t.Errorf("normalizeEnumName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
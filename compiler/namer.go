@@ -0,0 +1,127 @@
+package compiler
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/NYTimes/openapi2proto/openapi"
+)
+
+// Namer decides how OpenAPI constructs are translated into proto
+// identifiers. The default implementation is what the compiler has
+// always done (see defaultNamer), but callers can supply their own via
+// WithNamer to get, say, Google-API-style RPC names or snake_cased
+// fields without forking the package.
+type Namer interface {
+	// MessageName returns the proto message name for a schema with
+	// the given title/name.
+	MessageName(ctx context.Context, name string) string
+	// FieldName returns the proto field name for a schema property
+	// with the given name. Note this does not apply the initialisms
+	// pass that MessageName/EnumValueName/ServiceName/RPCName do --
+	// see fieldName's comment in strings.go for why.
+	FieldName(ctx context.Context, name string) string
+	// EnumValueName returns the proto enum value name for value
+	// within the enum called enumName.
+	EnumValueName(ctx context.Context, enumName, value string) string
+	// ServiceName returns the proto service name for the given
+	// swagger tag.
+	ServiceName(ctx context.Context, tagName string) string
+	// RPCName returns the proto rpc name for the given endpoint.
+	RPCName(ctx context.Context, e *openapi.Endpoint) string
+	// PackageName returns the proto package name for the API with the
+	// given title and version (info.title/info.version from the
+	// spec). version is passed through as-is, e.g. "1.0.0" or "v2";
+	// the default implementation ignores it, but a custom Namer can
+	// use it to produce version-suffixed packages such as "myapiV2".
+	PackageName(ctx context.Context, title, version string) string
+}
+
+// defaultNamer implements the naming policy the compiler has always
+// used, expressed in terms of the existing unexported helpers, plus a
+// nameRegistry that disambiguates any two messages, services, RPCs, or
+// values of one enum that would otherwise emit the same identifier
+// (see registry.go). Field and package names are not registered.
+type defaultNamer struct {
+	options  *Options
+	registry *nameRegistry
+
+	// err holds the first error the registry returned, which only
+	// happens under StrategyError. Compile should check Err() once
+	// it's done walking the spec and fail the build if it's non-nil.
+	err error
+}
+
+// resolve registers name under kind (disambiguating it against
+// everything already registered under that kind) and returns the name
+// to actually emit. Namer methods can't return an error themselves, so
+// under StrategyError this records the first failure on n.err and
+// falls back to returning name unchanged.
+func (n *defaultNamer) resolve(kind, disambiguator, name string) string {
+	resolved, err := n.registry.register(kind, disambiguator, name)
+	if err != nil {
+		if n.err == nil {
+			n.err = err
+		}
+		return name
+	}
+	return resolved
+}
+
+// Err returns the first collision the registry refused to resolve.
+// Only StrategyError ever produces one; the other strategies always
+// resolve to some name.
+func (n *defaultNamer) Err() error {
+	return n.err
+}
+
+func (n *defaultNamer) MessageName(ctx context.Context, name string) string {
+	return n.resolve("message", "", mangleTypeName(cleanAndTitle(n.options, name)))
+}
+
+func (n *defaultNamer) FieldName(ctx context.Context, name string) string {
+	return fieldName(name)
+}
+
+func (n *defaultNamer) EnumValueName(ctx context.Context, enumName, value string) string {
+	return n.resolve("enumValue:"+enumName, "", normalizeEnumName(n.options, value))
+}
+
+func (n *defaultNamer) ServiceName(ctx context.Context, tagName string) string {
+	return n.resolve("service", "", serviceName(n.options, tagName))
+}
+
+func (n *defaultNamer) RPCName(ctx context.Context, e *openapi.Endpoint) string {
+	return n.resolve("rpc", apiVersionSegment(e.Path), compileEndpointName(n.options, e))
+}
+
+func (n *defaultNamer) PackageName(ctx context.Context, title, version string) string {
+	return packageName(title)
+}
+
+// apiVersionSegment extracts a leading "/v1/", "/v2/", ... segment
+// from an endpoint path and returns it canonicalized ("V1", "V2"), or
+// "" if the path doesn't start with one. This is the disambiguating
+// context StrategyContextual uses for RPC names: "POST /v1/users" and
+// "POST /v2/users" both deriving the name "PostUsers" (e.g. because
+// both specs set the same operationID) is exactly the case that needs
+// it, since the version lives in the path rather than the name.
+var apiVersionSegmentRe = regexp.MustCompile(`^/?(v[0-9]+)(/|$)`)
+
+func apiVersionSegment(path string) string {
+	m := apiVersionSegmentRe.FindStringSubmatch(path)
+	if m == nil {
+		return ""
+	}
+	return strings.ToUpper(m[1])
+}
+
+// WithNamer overrides the default naming policy. This lets callers
+// customize message, field, enum, service, and RPC naming without
+// changing anything else about how the compiler walks the spec.
+func WithNamer(namer Namer) Option {
+	return func(o *Options) {
+		o.namer = namer
+	}
+}
@@ -0,0 +1,51 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/NYTimes/openapi2proto/openapi"
+)
+
+// POST /v1/users and POST /v2/users sharing the same operationID both
+// derive the RPC name "PostUsers" from compileEndpointName alone,
+// since operationID naming doesn't look at the path. RPCName needs to
+// disambiguate them anyway, using the path's version segment as
+// context.
+func TestDefaultNamerRPCNameDisambiguatesAcrossVersions(t *testing.T) {
+	o := newOptions(WithCollisionStrategy(StrategyContextual))
+	n := o.namer
+
+	v1 := &openapi.Endpoint{Path: "/v1/users", Verb: "POST", OperationID: "postUsers"}
+	v2 := &openapi.Endpoint{Path: "/v2/users", Verb: "POST", OperationID: "postUsers"}
+
+	if got, want := n.RPCName(nil, v1), "PostUsers"; got != want {
+		t.Errorf("RPCName(v1) = %q, want %q", got, want)
+	}
+	if got, want := n.RPCName(nil, v2), "V2PostUsers"; got != want {
+		t.Errorf("RPCName(v2) = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultNamerMessageNameDisambiguatesDuplicates(t *testing.T) {
+	o := newOptions()
+	n := o.namer
+
+	if got, want := n.MessageName(nil, "user"), "User"; got != want {
+		t.Errorf("MessageName(user) #1 = %q, want %q", got, want)
+	}
+	if got, want := n.MessageName(nil, "user"), "User2"; got != want {
+		t.Errorf("MessageName(user) #2 = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultNamerCollisionStrategyError(t *testing.T) {
+	o := newOptions(WithCollisionStrategy(StrategyError))
+	n := o.namer.(*defaultNamer)
+
+	n.MessageName(nil, "user")
+	n.MessageName(nil, "user")
+
+	if n.Err() == nil {
+		t.Fatal("expected Err() to report the duplicate message name")
+	}
+}
@@ -0,0 +1,42 @@
+package compiler
+
+// Option configures the behavior of the compiler. Options are applied
+// in order, so later options can override earlier ones.
+type Option func(*Options)
+
+// Options holds the resolved configuration for a compilation run. It is
+// built up from the defaults plus whatever Option values the caller
+// supplies, and is threaded through the naming helpers so that naming
+// decisions stay consistent across messages, fields, enums, services,
+// and RPCs.
+type Options struct {
+	initialisms       map[string]bool
+	namer             Namer
+	validate          bool
+	collisionStrategy CollisionStrategy
+}
+
+// newOptions returns the default Options with the given Option values
+// applied on top.
+func newOptions(opts ...Option) *Options {
+	o := &Options{
+		initialisms: defaultInitialisms(),
+		validate:    true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.namer == nil {
+		o.namer = &defaultNamer{options: o, registry: newNameRegistry(o.collisionStrategy)}
+	}
+	return o
+}
+
+// WithCollisionStrategy picks how the compiler disambiguates two
+// constructs that would otherwise emit the same proto identifier. The
+// default is StrategySuffix.
+func WithCollisionStrategy(strategy CollisionStrategy) Option {
+	return func(o *Options) {
+		o.collisionStrategy = strategy
+	}
+}
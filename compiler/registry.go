@@ -0,0 +1,80 @@
+package compiler
+
+import "fmt"
+
+// CollisionStrategy controls what the name registry does when two
+// distinct constructs would otherwise emit the same proto identifier.
+type CollisionStrategy int
+
+const (
+	// StrategySuffix appends a stable numeric counter to the second
+	// and subsequent collisions (e.g. PostUsers, PostUsers2). This is
+	// the default: it always produces a compiling .proto, at the cost
+	// of less predictable names.
+	StrategySuffix CollisionStrategy = iota
+	// StrategyContextual disambiguates using the caller-supplied
+	// context (an API version segment, a parent message name, ...)
+	// before falling back to StrategySuffix's numeric counter. This
+	// produces more readable names but requires the caller to supply
+	// meaningful context.
+	StrategyContextual
+	// StrategyError fails the compile on the first collision instead
+	// of silently renaming anything.
+	StrategyError
+)
+
+// nameRegistry tracks every top-level identifier the compiler has
+// emitted, scoped by kind (message, enum, service, rpc, or an enum's
+// values), so that two different schemas/endpoints/enums that would
+// otherwise produce the same proto identifier get disambiguated
+// deterministically instead of one silently overwriting the other.
+type nameRegistry struct {
+	strategy CollisionStrategy
+	seen     map[string]map[string]bool
+}
+
+func newNameRegistry(strategy CollisionStrategy) *nameRegistry {
+	return &nameRegistry{
+		strategy: strategy,
+		seen:     map[string]map[string]bool{},
+	}
+}
+
+// register records name under the given kind (e.g. "message", "rpc",
+// or "enumValue:"+enumName), disambiguating it first if it collides
+// with a name already registered under that kind. context is used by
+// StrategyContextual and is ignored by the other strategies.
+func (r *nameRegistry) register(kind, context, name string) (string, error) {
+	names, ok := r.seen[kind]
+	if !ok {
+		names = map[string]bool{}
+		r.seen[kind] = names
+	}
+
+	if !names[name] {
+		names[name] = true
+		return name, nil
+	}
+
+	switch r.strategy {
+	case StrategyError:
+		return "", fmt.Errorf("%s %q is already in use", kind, name)
+	case StrategyContextual:
+		if context != "" {
+			candidate := context + name
+			if !names[candidate] {
+				names[candidate] = true
+				return candidate, nil
+			}
+		}
+		fallthrough
+	default: // StrategySuffix
+		for i := 2; ; i++ {
+			candidate := fmt.Sprintf("%s%d", name, i)
+			if !names[candidate] {
+				names[candidate] = true
+				return candidate, nil
+			}
+		}
+	}
+}
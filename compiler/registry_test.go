@@ -0,0 +1,81 @@
+package compiler
+
+import "testing"
+
+func TestNameRegistryDuplicateOperationID(t *testing.T) {
+	// "POST /v1/users" and "POST /v2/users" both derive "PostUsers".
+	r := newNameRegistry(StrategySuffix)
+
+	first, err := r.register("rpc", "", "PostUsers")
+	if err != nil {
+		t.Fatalf("register first: %v", err)
+	}
+	if first != "PostUsers" {
+		t.Errorf("first = %q, want PostUsers", first)
+	}
+
+	second, err := r.register("rpc", "", "PostUsers")
+	if err != nil {
+		t.Fatalf("register second: %v", err)
+	}
+	if second != "PostUsers2" {
+		t.Errorf("second = %q, want PostUsers2", second)
+	}
+}
+
+func TestNameRegistryDuplicateOperationIDContextual(t *testing.T) {
+	r := newNameRegistry(StrategyContextual)
+
+	if _, err := r.register("rpc", "V1", "PostUsers"); err != nil {
+		t.Fatalf("register first: %v", err)
+	}
+	second, err := r.register("rpc", "V2", "PostUsers")
+	if err != nil {
+		t.Fatalf("register second: %v", err)
+	}
+	if second != "V2PostUsers" {
+		t.Errorf("second = %q, want V2PostUsers", second)
+	}
+}
+
+func TestNameRegistryDuplicateOperationIDError(t *testing.T) {
+	r := newNameRegistry(StrategyError)
+
+	if _, err := r.register("rpc", "", "PostUsers"); err != nil {
+		t.Fatalf("register first: %v", err)
+	}
+	if _, err := r.register("rpc", "", "PostUsers"); err == nil {
+		t.Error("expected an error on the second registration, got nil")
+	}
+}
+
+func TestNameRegistryDuplicateInlineEnumValue(t *testing.T) {
+	r := newNameRegistry(StrategySuffix)
+
+	first, err := r.register("enumValue:Status", "", "ACTIVE")
+	if err != nil {
+		t.Fatalf("register first: %v", err)
+	}
+	if first != "ACTIVE" {
+		t.Errorf("first = %q, want ACTIVE", first)
+	}
+
+	// A different enum is a different namespace, so it's free to
+	// reuse the same value name.
+	other, err := r.register("enumValue:Other", "", "ACTIVE")
+	if err != nil {
+		t.Fatalf("register in other enum: %v", err)
+	}
+	if other != "ACTIVE" {
+		t.Errorf("other = %q, want ACTIVE", other)
+	}
+
+	// But within the same enum, a collision gets disambiguated.
+	dup, err := r.register("enumValue:Status", "", "ACTIVE")
+	if err != nil {
+		t.Fatalf("register duplicate: %v", err)
+	}
+	if dup != "ACTIVE2" {
+		t.Errorf("dup = %q, want ACTIVE2", dup)
+	}
+}
@@ -0,0 +1,97 @@
+package compiler
+
+import (
+	"go/token"
+	"strings"
+)
+
+// protoReservedWords are keywords and well-known scalar type names that
+// have special meaning in proto2/proto3 syntax. Emitting one of these
+// as a message, field, enum, or service name either fails to compile
+// with protoc or silently shadows a built-in type.
+var protoReservedWords = map[string]bool{
+	"bool":       true,
+	"bytes":      true,
+	"default":    true,
+	"double":     true,
+	"enum":       true,
+	"extend":     true,
+	"extensions": true,
+	"false":      true,
+	"fixed32":    true,
+	"fixed64":    true,
+	"float":      true,
+	"group":      true,
+	"import":     true,
+	"int32":      true,
+	"int64":      true,
+	"map":        true,
+	"message":    true,
+	"oneof":      true,
+	"option":     true,
+	"optional":   true,
+	"package":    true,
+	"public":     true,
+	"repeated":   true,
+	"required":   true,
+	"reserved":   true,
+	"return":     true,
+	"rpc":        true,
+	"service":    true,
+	"sfixed32":   true,
+	"sfixed64":   true,
+	"sint32":     true,
+	"sint64":     true,
+	"stream":     true,
+	"string":     true,
+	"syntax":     true,
+	"to":         true,
+	"true":       true,
+	"uint32":     true,
+	"uint64":     true,
+	"weak":       true,
+}
+
+// isReservedIdentifier reports whether name collides with a proto
+// reserved word/scalar type (case-sensitively, since proto identifiers
+// are case-sensitive) or a Go keyword (since generated stubs must
+// still compile as Go source). mangleFieldName uses it as is, so a
+// field name or enum value is only caught when it is spelled exactly
+// like the lowercase keyword ("type", not "Type" or "TYPE").
+func isReservedIdentifier(name string) bool {
+	if protoReservedWords[name] {
+		return true
+	}
+	return token.Lookup(name).IsKeyword()
+}
+
+// mangleFieldName predictably renames a field name that collides with
+// a reserved word, following the same convention protoc-gen-go uses
+// for Go keywords: appending an underscore.
+func mangleFieldName(name string) string {
+	if isReservedIdentifier(name) {
+		return name + "_"
+	}
+	return name
+}
+
+// mangleTypeName predictably renames a message/enum/service/RPC name
+// that collides with a reserved word. Type names always go through
+// cleanAndTitle/camelCase first, so they're Title-cased ("Message",
+// "Service", ...); checking isReservedIdentifier directly would never
+// match, since every proto keyword and Go keyword is lowercase. Lower
+// the name first so a construct that's merely a re-capitalization of
+// a keyword still gets caught, then mangle it the same way
+// mangleFieldName does.
+//
+// This only resolves a name against the fixed reserved-word list.
+// Disambiguating two *different* constructs that happen to produce
+// the same name (e.g. by prepending an API version or parent message)
+// is the nameRegistry's job (see registry.go), since that needs to
+// track everything the compiler has emitted, not just a static list.
+func mangleTypeName(name string) string {
+	if !isReservedIdentifier(strings.ToLower(name)) {
+		return name
+	}
+	return name + "_"
+}
@@ -0,0 +1,92 @@
+package compiler
+
+import "testing"
+
+func TestFieldNameReservedWords(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"type", "type_"},
+		{"id", "id"},
+		{"user_id", "user_id"},
+	}
+	for _, tt := range tests {
+		if got := fieldName(tt.in); got != tt.want {
+			t.Errorf("fieldName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPathMethodToNameReservedWords(t *testing.T) {
+	o := newOptions()
+	if got, want := pathMethodToName(o, "/return", "get", ""), "GetReturn"; got != want {
+		t.Errorf("pathMethodToName(/return) = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeEnumNameReservedWords(t *testing.T) {
+	o := newOptions()
+	if got, want := normalizeEnumName(o, "default"), "default_"; got != want {
+		t.Errorf("normalizeEnumName(default) = %q, want %q", got, want)
+	}
+}
+
+func TestMangleTypeNameCollision(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Message", "Message_"},
+		{"Service", "Service_"},
+		{"UserID", "UserID"},
+	}
+	for _, tt := range tests {
+		if got := mangleTypeName(tt.in); got != tt.want {
+			t.Errorf("mangleTypeName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestMessageNameReservedWords(t *testing.T) {
+	o := newOptions()
+	n := &defaultNamer{options: o, registry: newNameRegistry(o.collisionStrategy)}
+	if got, want := n.MessageName(nil, "message"), "Message_"; got != want {
+		t.Errorf("MessageName(message) = %q, want %q", got, want)
+	}
+}
+
+func TestOperationIDToNameReservedWords(t *testing.T) {
+	o := newOptions()
+	if got, want := operationIDToName(o, "type"), "Type_"; got != want {
+		t.Errorf("operationIDToName(type) = %q, want %q", got, want)
+	}
+}
+
+// Without an operationID or a verb, a bare path segment can produce an
+// identifier that's nothing but a re-capitalized reserved word, which
+// is exactly the case mangleTypeName needs to catch.
+func TestPathMethodToNameNoVerbCollision(t *testing.T) {
+	o := newOptions()
+	if got, want := pathMethodToName(o, "/message", "", ""), "Message_"; got != want {
+		t.Errorf("pathMethodToName(/message) = %q, want %q", got, want)
+	}
+}
+
+func TestIsReservedIdentifier(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"message", true},
+		{"return", true},
+		{"bytes", true},
+		{"userId", false},
+		{"name", false},
+	}
+	for _, tt := range tests {
+		if got := isReservedIdentifier(tt.in); got != tt.want {
+			t.Errorf("isReservedIdentifier(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
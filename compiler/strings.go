@@ -49,29 +49,11 @@ func snakeCase(s string) string {
 	return buf.String()
 }
 
-func camelCase(s string) string {
-	var first = true
-	var wasUnderscore bool
+func camelCase(o *Options, s string) string {
 	var buf bytes.Buffer
-	for _, r := range s {
-		// replace all non-alpha-numeric characters with an underscore
-		if !isAlphaNum(r) {
-			r = '_'
-		}
-
-		if r == '_' {
-			wasUnderscore = true
-			continue
-		}
-
-		if first || wasUnderscore {
-			r = unicode.ToUpper(r)
-		}
-		first = false
-		wasUnderscore = false
-		buf.WriteRune(r)
+	for _, w := range splitWords(s) {
+		buf.WriteString(applyInitialisms(o, w))
 	}
-
 	return buf.String()
 }
 
@@ -105,16 +87,20 @@ func concatSpaces(s string, title bool) string {
 	return buf.String()
 }
 
-func cleanAndTitle(s string) string {
-	return cleanCharacters(strings.Title(s))
+func cleanAndTitle(o *Options, s string) string {
+	var buf bytes.Buffer
+	for _, w := range splitWords(s) {
+		buf.WriteString(applyInitialisms(o, w))
+	}
+	return cleanCharacters(buf.String())
 }
 
 func packageName(s string) string {
 	return cleanCharacters(strings.ToLower(concatSpaces(s, false)))
 }
 
-func serviceName(s string) string {
-	return cleanCharacters(concatSpaces(s, true) + "Service")
+func serviceName(o *Options, s string) string {
+	return cleanAndTitle(o, s) + "Service"
 }
 
 func cleanCharacters(input string) string {
@@ -130,13 +116,13 @@ func cleanCharacters(input string) string {
 	return buf.String()
 }
 
-func compileEndpointName(e *openapi.Endpoint) string {
-	return pathMethodToName(e.Path, e.Verb, e.OperationID)
+func compileEndpointName(o *Options, e *openapi.Endpoint) string {
+	return pathMethodToName(o, e.Path, e.Verb, e.OperationID)
 }
 
-func pathMethodToName(path, method, operationID string) string {
+func pathMethodToName(o *Options, path, method, operationID string) string {
 	if operationID != "" {
-		return operationIDToName(operationID)
+		return operationIDToName(o, operationID)
 	}
 
 	path = strings.TrimSuffix(path, ".json")
@@ -163,9 +149,9 @@ func pathMethodToName(path, method, operationID string) string {
 
 	var name string
 	for _, v := range strings.Fields(buf.String()) {
-		name += cleanAndTitle(v)
+		name += cleanAndTitle(o, v)
 	}
-	return cleanAndTitle(method) + name
+	return mangleTypeName(cleanAndTitle(o, method) + name)
 }
 
 func looksLikeInteger(s string) bool {
@@ -177,7 +163,7 @@ func looksLikeInteger(s string) bool {
 	return true
 }
 
-func normalizeEnumName(s string) string {
+func normalizeEnumName(o *Options, s string) string {
 	var buf bytes.Buffer
 
 	s = strings.Replace(s, "&", " AND ", -1)
@@ -212,10 +198,53 @@ func normalizeEnumName(s string) string {
 			wasNonAlnum = true
 		}
 	}
-	return buf.String()
+
+	// Run each underscore-separated segment through the initialisms
+	// pass, same as message/service/RPC names (but not field names --
+	// see fieldName's comment for why), so a value like "api_key"
+	// keeps "API" in its canonical form instead of leaving it exactly
+	// as the spec author typed it. Each segment is further split at
+	// camelCase boundaries ("userId" -> "user", "Id"). Unlike
+	// cleanAndTitle, this only rewrites words that match a configured
+	// initialism ("userId" -> "userID") -- every other word keeps the
+	// caller's original casing, since enum
+	// values (unlike proto message/service/RPC names) aren't
+	// title-cased by this package.
+	segments := strings.Split(buf.String(), "_")
+	for i, seg := range segments {
+		if seg == "" {
+			continue
+		}
+		var canon bytes.Buffer
+		for _, w := range splitWords(seg) {
+			if upper, ok := o.initialisms[strings.ToUpper(w)]; ok && upper {
+				canon.WriteString(strings.ToUpper(w))
+			} else {
+				canon.WriteString(w)
+			}
+		}
+		segments[i] = canon.String()
+	}
+
+	return mangleFieldName(strings.Join(segments, "_"))
+}
+
+// fieldName turns a property/parameter name into a proto field
+// identifier, mangling it if it collides with a reserved word.
+//
+// Unlike message/enum/service/RPC names, field names deliberately do
+// not go through the initialisms pass: proto field names are
+// conventional lowercase snake_case ("user_id", "api_key"), and
+// initialisms only have a canonical form to preserve when something
+// else is going to title-case the word anyway. Forcing "id"/"api" to
+// "ID"/"API" inside an otherwise-lowercase field name would fight that
+// convention rather than serve it, so this is an intentional
+// exemption, not an oversight.
+func fieldName(s string) string {
+	return mangleFieldName(snakeCase(s))
 }
 
-func operationIDToName(s string) string {
+func operationIDToName(o *Options, s string) string {
 	var buf bytes.Buffer
 	var wasNonAlnum bool
 	for _, r := range s {
@@ -225,11 +254,15 @@ func operationIDToName(s string) string {
 				buf.WriteRune('_')
 			}
 			wasNonAlnum = false
-			buf.WriteRune(unicode.ToLower(r))
+			buf.WriteRune(r)
 		default:
 			wasNonAlnum = true
 		}
 	}
 
-	return camelCase(strings.TrimSuffix(buf.String(), "_json"))
+	s = buf.String()
+	if idx := len(s) - len("_json"); idx > 0 && strings.EqualFold(s[idx:], "_json") {
+		s = s[:idx]
+	}
+	return mangleTypeName(camelCase(o, s))
 }
@@ -0,0 +1,149 @@
+package compiler
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/NYTimes/openapi2proto/openapi"
+)
+
+// ValidationError aggregates every problem found while validating a
+// spec, rather than stopping at the first one, so callers get the
+// full report (JSON-pointer location plus message) in one pass,
+// similar to what `swagger validate` prints. Issues carry no file
+// name or line number.
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+// ValidationIssue is a single validation failure.
+type ValidationIssue struct {
+	// Pointer is a JSON-pointer-style location within the spec, e.g.
+	// "/paths/~1users~1{id}/get/operationId".
+	Pointer string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d validation error(s):", len(e.Issues))
+	for _, issue := range e.Issues {
+		fmt.Fprintf(&b, "\n  %s: %s", issue.Pointer, issue.Message)
+	}
+	return b.String()
+}
+
+func (e *ValidationError) add(pointer, format string, args ...interface{}) {
+	e.Issues = append(e.Issues, ValidationIssue{
+		Pointer: pointer,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// jsonPointerEscape escapes a string for use as a single token within
+// a JSON Pointer (RFC 6901): "~" becomes "~0" and "/" becomes "~1".
+// The "~" replacement must run first, or a "/" turned into "~1" would
+// have its "~" escaped a second time.
+func jsonPointerEscape(s string) string {
+	s = strings.Replace(s, "~", "~0", -1)
+	s = strings.Replace(s, "/", "~1", -1)
+	return s
+}
+
+// pathPointer builds the JSON Pointer for a location under a spec's
+// "paths" entry, e.g. pathPointer("/users/{id}", "get", "operationId")
+// -> "/paths/~1users~1{id}/get/operationId". path is escaped as a
+// single token since it's used verbatim as the key of the paths
+// object; it already carries its own leading "/", which becomes part
+// of that escaped token rather than a pointer path separator.
+func pathPointer(path string, rest ...string) string {
+	tokens := append([]string{"paths", jsonPointerEscape(path)}, rest...)
+	return "/" + strings.Join(tokens, "/")
+}
+
+// WithValidation sets Options.validate, which is true by default. No
+// code in this package reads that setting: validateEndpoints runs only
+// when it is called directly, whatever the setting says.
+func WithValidation(enabled bool) Option {
+	return func(o *Options) {
+		o.validate = enabled
+	}
+}
+
+// validateEndpoints enforces the invariants that are checkable from a
+// slice of endpoints alone: every operationID is unique once run
+// through operationIDToName, and every path template is well-formed
+// (no mismatched braces, no empty or duplicate parameter names).
+//
+// KNOWN GAP, not silently closed: the originating request also asked
+// for a JSON-Schema pass against the OpenAPI 2.0/3.0 meta-schema, $ref
+// resolution, and an enum-has-at-least-one-value check. None of those
+// are implemented here -- they all need the rest of the parsed spec
+// (definitions/components, a $ref resolver) that this package doesn't
+// parse or carry today, and the meta-schema pass specifically needs a
+// JSON-Schema validation library, which is a dependency decision for
+// whoever wires this up to Compile, not something to take on silently
+// here. This is a deliberate, reported scope reduction -- flagged back
+// to the request owner -- not a claim that the request is fully
+// satisfied; track the meta-schema/$ref/enum checks as a follow-up
+// once Compile's loading phase exists and can hand validateEndpoints
+// (or a replacement) the full spec instead of a flattened endpoint
+// list.
+func validateEndpoints(o *Options, endpoints []*openapi.Endpoint) *ValidationError {
+	verr := &ValidationError{}
+	validateOperationIDUniqueness(o, endpoints, verr)
+	validatePathParameterTemplates(endpoints, verr)
+	if len(verr.Issues) == 0 {
+		return nil
+	}
+	return verr
+}
+
+func validateOperationIDUniqueness(o *Options, endpoints []*openapi.Endpoint, verr *ValidationError) {
+	seen := map[string]string{}
+	for _, e := range endpoints {
+		if e.OperationID == "" {
+			continue
+		}
+		pointer := pathPointer(e.Path, strings.ToLower(e.Verb), "operationId")
+		name := operationIDToName(o, e.OperationID)
+		if other, ok := seen[name]; ok {
+			verr.add(pointer, "operationId %q normalizes to %q, which collides with %q", e.OperationID, name, other)
+		}
+		seen[name] = e.OperationID
+	}
+}
+
+// pathParameterRe matches a single "{name}" placeholder in a path
+// template.
+var pathParameterRe = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// validatePathParameterTemplates checks that every path template is
+// well-formed: it has as many { as } braces (their order is not
+// checked), every placeholder has a name, and no
+// name is repeated (a single path parameter value can't satisfy
+// "/a/{id}/b/{id}" twice).
+func validatePathParameterTemplates(endpoints []*openapi.Endpoint, verr *ValidationError) {
+	for _, e := range endpoints {
+		pointer := pathPointer(e.Path)
+
+		if strings.Count(e.Path, "{") != strings.Count(e.Path, "}") {
+			verr.add(pointer, "path template has mismatched { and } braces")
+			continue
+		}
+
+		seen := map[string]bool{}
+		for _, m := range pathParameterRe.FindAllStringSubmatch(e.Path, -1) {
+			name := m[1]
+			if name == "" {
+				verr.add(pointer, "path template has an empty path parameter placeholder \"{}\"")
+				continue
+			}
+			if seen[name] {
+				verr.add(pointer, "path parameter %q appears more than once in the path template", name)
+			}
+			seen[name] = true
+		}
+	}
+}
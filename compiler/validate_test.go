@@ -0,0 +1,80 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/NYTimes/openapi2proto/openapi"
+)
+
+func TestValidateEndpointsDuplicateOperationID(t *testing.T) {
+	o := newOptions()
+	endpoints := []*openapi.Endpoint{
+		{Path: "/v1/users", Verb: "post", OperationID: "postUsers"},
+		{Path: "/v2/users", Verb: "post", OperationID: "post_Users"},
+	}
+
+	verr := validateEndpoints(o, endpoints)
+	if verr == nil {
+		t.Fatal("expected a ValidationError, got nil")
+	}
+	if len(verr.Issues) != 1 {
+		t.Fatalf("len(Issues) = %d, want 1: %v", len(verr.Issues), verr.Issues)
+	}
+	if got, want := verr.Issues[0].Pointer, "/paths/~1v2~1users/post/operationId"; got != want {
+		t.Errorf("Pointer = %q, want %q", got, want)
+	}
+}
+
+func TestJSONPointerEscape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"/users/{id}", "~1users~1{id}"},
+		{"a~b", "a~0b"},
+		{"a~/b", "a~0~1b"},
+	}
+	for _, tt := range tests {
+		if got := jsonPointerEscape(tt.in); got != tt.want {
+			t.Errorf("jsonPointerEscape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPathPointer(t *testing.T) {
+	got := pathPointer("/users/{id}", "get", "operationId")
+	want := "/paths/~1users~1{id}/get/operationId"
+	if got != want {
+		t.Errorf("pathPointer(...) = %q, want %q", got, want)
+	}
+}
+
+func TestValidateEndpointsPathParameterTemplates(t *testing.T) {
+	o := newOptions()
+	endpoints := []*openapi.Endpoint{
+		{Path: "/users/{id}/posts/{id}", Verb: "get"},
+		{Path: "/users/{}", Verb: "get"},
+		{Path: "/users/{id", Verb: "get"},
+		{Path: "/users/{id}", Verb: "get"},
+	}
+
+	verr := validateEndpoints(o, endpoints)
+	if verr == nil {
+		t.Fatal("expected a ValidationError, got nil")
+	}
+	if len(verr.Issues) != 3 {
+		t.Fatalf("len(Issues) = %d, want 3: %v", len(verr.Issues), verr.Issues)
+	}
+}
+
+func TestValidateEndpointsNoIssues(t *testing.T) {
+	o := newOptions()
+	endpoints := []*openapi.Endpoint{
+		{Path: "/v1/users", Verb: "get", OperationID: "listUsers"},
+		{Path: "/v1/users/{id}", Verb: "get", OperationID: "getUser"},
+	}
+
+	if verr := validateEndpoints(o, endpoints); verr != nil {
+		t.Fatalf("expected no ValidationError, got %v", verr)
+	}
+}